@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ytka/textforge/internal/agents"
+	"github.com/ytka/textforge/internal/process"
+	"github.com/ytka/textforge/internal/toolbox"
+	"github.com/ytka/textforge/internal/tui"
+)
+
+// defaultAgentConfigPath returns ~/.textforge/agents.yaml, the default
+// location for agent definitions.
+func defaultAgentConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".textforge", "agents.yaml")
+}
+
+// loadAgentRegistry reads the agent definitions pointed to by
+// --agent-config.
+func loadAgentRegistry() (*agents.Registry, error) {
+	data, err := os.ReadFile(agentConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent config %s: %w", agentConfigPath, err)
+	}
+	return agents.LoadRegistry(data)
+}
+
+// builtinToolbox returns the full set of built-in tools an agent can be
+// granted. run_command is restricted to a small allow-list of safe, common
+// programs. web_search is only included once --web-search-base-url is set;
+// without a configured backend it would fail every call with a malformed
+// URL, so it's left out of the toolbox entirely rather than granted broken.
+func builtinToolbox() (*toolbox.Registry, error) {
+	tools := []toolbox.Tool{
+		toolbox.ReadFileTool{},
+		toolbox.ListDirTool{},
+		toolbox.ModifyFileTool{},
+		toolbox.RunCommandTool{Allowed: []string{"go", "git", "ls", "cat"}},
+	}
+
+	if webSearchBaseURL != "" {
+		apikey, err := resolveWebSearchAPIKey()
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, toolbox.WebSearchTool{BaseURL: webSearchBaseURL, APIKey: apikey})
+	}
+
+	return toolbox.NewRegistry(tools...), nil
+}
+
+// resolveWebSearchAPIKey reads the web_search backend's API key from
+// --web-search-api-key-file, falling back to $WEB_SEARCH_API_KEY_FILE, the
+// same file-path convention providerAPIKeyFilePath uses for provider keys.
+func resolveWebSearchAPIKey() (string, error) {
+	path := webSearchAPIKeyFile
+	if path == "" {
+		path = os.Getenv("WEB_SEARCH_API_KEY_FILE")
+	}
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read web search API key file %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// confirmToolCall asks the user to approve a tool call via tui.Confirm,
+// unless --yes was passed.
+func confirmToolCall(toolName, argsJSON string) bool {
+	if autoConfirmTools {
+		return true
+	}
+	return tui.Confirm(fmt.Sprintf("Run tool %s with arguments %s?", toolName, argsJSON))
+}
+
+// runAgent drives the --agent path: it loads agentName from --agent-config,
+// restricts it to its declared tools, and lets the model operate on the
+// working tree through the agentic loop instead of producing a single
+// shaped output. inputFiles, if any, are read and passed along as the
+// input the prompt is optimized against.
+func runAgent(ctx context.Context, inputFiles []string) error {
+	registry, err := loadAgentRegistry()
+	if err != nil {
+		return err
+	}
+	agent, err := registry.Get(agentName)
+	if err != nil {
+		return err
+	}
+	builtin, err := builtinToolbox()
+	if err != nil {
+		return err
+	}
+	toolRegistry, err := agent.Toolbox(builtin)
+	if err != nil {
+		return err
+	}
+
+	prompt, err := resolvePromptText()
+	if err != nil {
+		return err
+	}
+	input, err := readInputFilesContent(inputFiles)
+	if err != nil {
+		return err
+	}
+
+	provider, err := makeGAIFunc(c.Model)
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+	shaper := process.NewShaper(provider, c.MaxCompletionRepeatCount, c.UseFirstCodeBlock, c.PromptOptimize)
+
+	result, err := shaper.ShapeWithAgent(ctx, agent, toolRegistry, prompt, input, confirmToolCall)
+	if err != nil {
+		return fmt.Errorf("failed to run agent %s: %w", agentName, err)
+	}
+	fmt.Print(result.Result)
+	return nil
+}
+
+// resolvePromptText returns the prompt text from --prompt-path if set,
+// otherwise --prompt.
+func resolvePromptText() (string, error) {
+	if c.PromptPath != "" {
+		data, err := os.ReadFile(c.PromptPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read prompt file %s: %w", c.PromptPath, err)
+		}
+		return string(data), nil
+	}
+	return c.Prompt, nil
+}
+
+// readInputFilesContent concatenates the contents of inputFiles, if any.
+// Agent runs are typically driven by tools operating on the working tree
+// rather than a single input buffer, so inputFiles is commonly empty there.
+func readInputFilesContent(inputFiles []string) (string, error) {
+	if len(inputFiles) == 0 {
+		return "", nil
+	}
+
+	parts := make([]string, 0, len(inputFiles))
+	for _, path := range inputFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read input file %s: %w", path, err)
+		}
+		parts = append(parts, string(data))
+	}
+	return strings.Join(parts, "\n"), nil
+}
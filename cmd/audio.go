@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ytka/textforge/internal/openai"
+)
+
+var transcribeOnly bool
+
+// audioExtensions are the file extensions transcribeAudioInputs treats as
+// audio to be sent to Whisper rather than read as text.
+var audioExtensions = map[string]bool{
+	".mp3":  true,
+	".wav":  true,
+	".m4a":  true,
+	".ogg":  true,
+	".flac": true,
+	".webm": true,
+}
+
+func isAudioFile(path string) bool {
+	return audioExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+// transcribeAudioInputs replaces every audio file in inputFiles with a
+// sibling .txt file holding its Whisper transcript, so the rest of the
+// pipeline can keep treating every input path as plain text. Non-audio
+// paths are returned unchanged. Whisper bills per minute of audio rather
+// than per token, so transcription cost doesn't fit openai.UsageCost's
+// token-based model; it's returned separately as a USD total for the
+// caller to report alongside the token-based costs.
+func transcribeAudioInputs(ctx context.Context, inputFiles []string) ([]string, float64, error) {
+	var totalCost float64
+
+	out := make([]string, 0, len(inputFiles))
+	var audio *openai.AudioClient
+	for _, path := range inputFiles {
+		if !isAudioFile(path) {
+			out = append(out, path)
+			continue
+		}
+
+		if audio == nil {
+			apikey, err := getAPIKey()
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to get API key for audio transcription: %w", err)
+			}
+			audio = openai.NewAudioClient(apikey, c.LogAPILevel)
+		}
+
+		tr, err := audio.Transcribe(ctx, path)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to transcribe %s: %w", path, err)
+		}
+
+		txtPath := path + ".transcript.txt"
+		if err := os.WriteFile(txtPath, []byte(tr.Text), 0o644); err != nil {
+			return nil, 0, fmt.Errorf("failed to write transcript for %s: %w", path, err)
+		}
+		if transcribeOnly {
+			fmt.Printf("transcribed %s -> %s\n", path, txtPath)
+		}
+		out = append(out, txtPath)
+		totalCost += tr.Cost()
+	}
+	return out, totalCost, nil
+}
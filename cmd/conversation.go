@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/ytka/textforge/internal/conversations"
+	"github.com/ytka/textforge/internal/openai"
+	"github.com/ytka/textforge/internal/process"
+)
+
+var conversationsDBPath string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&conversationsDBPath, "conversations-db", defaultConversationsDBPath(), "Path to the SQLite conversation store")
+
+	rootCmd.AddCommand(newConversationCmd, replyCmd, viewCmd, lsConversationsCmd, rmConversationCmd, editCmd)
+}
+
+func defaultConversationsDBPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "conversations.db"
+	}
+	return filepath.Join(home, ".textforge", "conversations.db")
+}
+
+func openConversationStore() (*conversations.Store, error) {
+	if dir := filepath.Dir(conversationsDBPath); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	return conversations.Open(conversationsDBPath)
+}
+
+func parseConversationID(arg string) (int64, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid conversation id %q: %w", arg, err)
+	}
+	return id, nil
+}
+
+// conversationCost aggregates the dollar cost of every message stored in
+// conversation convID, priced per model via openai.ChatCompletionCost since
+// a conversation can span more than one model across its replies. complete
+// is false if any model with usage isn't in openai's pricing table, in
+// which case cost only reflects the models that were.
+func conversationCost(store *conversations.Store, convID int64) (cost float64, complete bool, err error) {
+	usage, err := store.UsageByModel(convID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	complete = true
+	for model, u := range usage {
+		modelCost, ok := openai.ChatCompletionCost(model, u.PromptTokens, u.CompletionTokens)
+		if !ok {
+			complete = false
+			continue
+		}
+		cost += modelCost
+	}
+	return cost, complete, nil
+}
+
+// printConversationCost prints convID's aggregated cost, in the same
+// "known total, or honestly unknown" style as showCosts.
+func printConversationCost(store *conversations.Store, convID int64) error {
+	cost, complete, err := conversationCost(store, convID)
+	if err != nil {
+		return err
+	}
+	if complete {
+		fmt.Printf("Total cost: $%f\n", cost)
+	} else {
+		fmt.Println("Total cost: unknown")
+	}
+	return nil
+}
+
+var newConversationCmd = &cobra.Command{
+	Use:   "new <title>",
+	Short: "Start a new conversation",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		store, err := openConversationStore()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = store.Close()
+		}()
+
+		conv, err := store.NewConversation(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created conversation %d: %s\n", conv.ID, conv.Title)
+		return nil
+	},
+}
+
+var replyCmd = &cobra.Command{
+	Use:   "reply <conversation-id> <prompt>",
+	Short: "Send the next turn of a conversation and print the reply",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		convID, err := parseConversationID(args[0])
+		if err != nil {
+			return err
+		}
+
+		store, err := openConversationStore()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = store.Close()
+		}()
+
+		handle, err := conversations.NewHandle(store, convID)
+		if err != nil {
+			return err
+		}
+
+		provider, err := makeGAIFunc(c.Model)
+		if err != nil {
+			return fmt.Errorf("failed to create provider: %w", err)
+		}
+		shaper := process.NewShaper(provider, c.MaxCompletionRepeatCount, c.UseFirstCodeBlock, c.PromptOptimize)
+
+		result, err := shaper.ShapeTextInConversation(context.Background(), handle, args[1], "", c.Model)
+		if err != nil {
+			return fmt.Errorf("failed to reply: %w", err)
+		}
+		fmt.Print(result.Result)
+
+		if c.ShowCost {
+			return printConversationCost(store, convID)
+		}
+		return nil
+	},
+}
+
+var viewCmd = &cobra.Command{
+	Use:   "view <conversation-id> [leaf-message-id]",
+	Short: "Render a conversation branch",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		convID, err := parseConversationID(args[0])
+		if err != nil {
+			return err
+		}
+
+		store, err := openConversationStore()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = store.Close()
+		}()
+
+		leafID := convID
+		if len(args) == 2 {
+			leafID, err = parseConversationID(args[1])
+			if err != nil {
+				return err
+			}
+		} else {
+			latest, err := store.LatestMessage(convID)
+			if err != nil {
+				return err
+			}
+			leafID = latest.ID
+		}
+
+		branch, err := store.Branch(leafID)
+		if err != nil {
+			return err
+		}
+		for _, m := range branch {
+			fmt.Printf("[%d] %s: %s\n", m.ID, m.Role, m.Content)
+		}
+
+		if c.ShowCost {
+			return printConversationCost(store, convID)
+		}
+		return nil
+	},
+}
+
+var lsConversationsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List conversations",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		store, err := openConversationStore()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = store.Close()
+		}()
+
+		convs, err := store.ListConversations()
+		if err != nil {
+			return err
+		}
+		for _, conv := range convs {
+			promptTokens, completionTokens, err := store.TotalUsage(conv.ID)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d\t%s\t%s\t(%d prompt + %d completion tokens)",
+				conv.ID, conv.Title, conv.CreatedAt.Format("2006-01-02 15:04"), promptTokens, completionTokens)
+
+			if c.ShowCost {
+				cost, complete, err := conversationCost(store, conv.ID)
+				if err != nil {
+					return err
+				}
+				if complete {
+					fmt.Printf("\t$%f", cost)
+				} else {
+					fmt.Printf("\tcost unknown")
+				}
+			}
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+var rmConversationCmd = &cobra.Command{
+	Use:   "rm <conversation-id>",
+	Short: "Delete a conversation",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		convID, err := parseConversationID(args[0])
+		if err != nil {
+			return err
+		}
+
+		store, err := openConversationStore()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = store.Close()
+		}()
+
+		return store.DeleteConversation(convID)
+	},
+}
+
+var editCmd = &cobra.Command{
+	Use:   "edit <message-id> <new-content>",
+	Short: "Branch a conversation by replacing a message's content",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		messageID, err := parseConversationID(args[0])
+		if err != nil {
+			return err
+		}
+
+		store, err := openConversationStore()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = store.Close()
+		}()
+
+		branched, err := store.Edit(messageID, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created branch message %d\n", branched.ID)
+		return nil
+	},
+}
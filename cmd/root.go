@@ -11,6 +11,11 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ytka/textforge/internal/ioutil"
 	"github.com/ytka/textforge/internal/openai"
+	"github.com/ytka/textforge/internal/providers"
+	_ "github.com/ytka/textforge/internal/providers/anthropic"
+	_ "github.com/ytka/textforge/internal/providers/google"
+	_ "github.com/ytka/textforge/internal/providers/ollama"
+	_ "github.com/ytka/textforge/internal/providers/openai"
 	"github.com/ytka/textforge/internal/runner"
 	"github.com/ytka/textforge/internal/steps"
 	"github.com/ytka/textforge/internal/tui"
@@ -19,13 +24,21 @@ import (
 var (
 	ErrorAPIKeyFileNotFound = errors.New("API key file not found")
 	c                       runner.Config
+	provider                string
+	baseURL                 string
+	apiKeyFile              string
+	agentName               string
+	agentConfigPath         string
+	autoConfirmTools        bool
+	webSearchBaseURL        string
+	webSearchAPIKeyFile     string
 	rootCmd                 = &cobra.Command{
 		Use:   "textforge",
 		Short: "textforge is a tool designed to shape and transform text using OpenAI's GPT model.",
 		Long:  "textforge is a tool designed to shape and transform text using OpenAI's GPT model.",
 		RunE: func(_ *cobra.Command, args []string) error {
-			if !checkAPIKeyFileExists() {
-				return fmt.Errorf("%w: %s", ErrorAPIKeyFileNotFound, getAPIKeyFilePath())
+			if providerRequiresAPIKey(provider) && !checkProviderAPIKeyFileExists(provider) {
+				return fmt.Errorf("%w: %s", ErrorAPIKeyFileNotFound, providerAPIKeyFilePath(provider))
 			}
 
 			inputFiles := args
@@ -37,7 +50,35 @@ var (
 				inputFiles = files
 			}
 			ctx := context.Background()
-			return doRun(ctx, inputFiles, makeGAIFunc)
+
+			inputFiles, audioCost, err := transcribeAudioInputs(ctx, inputFiles)
+			if err != nil {
+				return err
+			}
+			if transcribeOnly {
+				if c.ShowCost {
+					showCosts(nil, audioCost)
+				}
+				return nil
+			}
+
+			if agentName != "" {
+				return runAgent(ctx, inputFiles)
+			}
+
+			if c.SchemaPath != "" {
+				return runStructured(ctx, inputFiles)
+			}
+
+			if c.JSON {
+				return runJSON(ctx, inputFiles)
+			}
+
+			if c.Stream {
+				return runStream(ctx, inputFiles)
+			}
+
+			return doRun(ctx, inputFiles, makeGAIFunc, audioCost)
 		},
 	}
 )
@@ -55,7 +96,20 @@ func init() {
 	rootCmd.Flags().IntVarP(&c.MaxTokens, "max-tokens", "t", 0, "Max tokens to generate")
 	rootCmd.Flags().IntVar(&c.MaxCompletionRepeatCount, "max-completion-repeat-count", 1, "Max completion repeat count")
 
+	// Provider options
+	rootCmd.Flags().StringVar(&provider, "provider", "openai", fmt.Sprintf("AI provider to use (%s)", strings.Join(providers.Names(), ", ")))
+	rootCmd.Flags().StringVar(&baseURL, "base-url", "", "Override the provider's default API base URL")
+	rootCmd.Flags().StringVar(&apiKeyFile, "api-key-file", "", "Path to a file containing the provider's API key (defaults to $<PROVIDER>_API_KEY_FILE)")
+
+	// Agent options
+	rootCmd.Flags().StringVarP(&agentName, "agent", "a", "", "Name of the agent to run with tool calling enabled (see --agent-config)")
+	rootCmd.Flags().StringVar(&agentConfigPath, "agent-config", defaultAgentConfigPath(), "Path to the YAML file defining agents and their toolboxes")
+	rootCmd.Flags().BoolVar(&autoConfirmTools, "yes", false, "Don't ask for confirmation before running a tool call")
+	rootCmd.Flags().StringVar(&webSearchBaseURL, "web-search-base-url", "", "Base URL of the web_search tool's backend API; web_search is only granted to agents once this is set")
+	rootCmd.Flags().StringVar(&webSearchAPIKeyFile, "web-search-api-key-file", "", "Path to a file containing the web_search backend's API key (defaults to $WEB_SEARCH_API_KEY_FILE)")
+
 	// Stdout messages options
+	rootCmd.Flags().BoolVar(&c.Stream, "stream", false, "Stream the response as it's generated instead of waiting for the full completion")
 	rootCmd.Flags().BoolVarP(&c.DryRun, "dry-run", "D", false, "Dry run")
 	rootCmd.Flags().BoolVarP(&c.Verbose, "verbose", "v", false, "Verbose output")
 	rootCmd.Flags().BoolVarP(&c.Silent, "silent", "s", false, "Suppress output")
@@ -64,6 +118,11 @@ func init() {
 
 	// Input file options
 	rootCmd.Flags().StringVarP(&c.InputFileList, "input-file-list", "i", "", "Input file list")
+	rootCmd.Flags().BoolVar(&transcribeOnly, "transcribe-only", false, "Transcribe audio inputs and write the transcript without shaping it")
+
+	// Structured output options
+	rootCmd.Flags().StringVar(&c.SchemaPath, "schema", "", "Path to a JSON Schema file; the response is requested and validated as matching JSON")
+	rootCmd.Flags().BoolVar(&c.JSON, "json", false, "Request a JSON response (implied, with validation, by --schema)")
 
 	// Debug options
 	rootCmd.Flags().StringVarP(&c.LogAPILevel, "log-api-level", "l", "", "API log level: info, debug")
@@ -90,8 +149,8 @@ func Execute(version, commit, date, builtBy string) {
 	}
 }
 
-func makeGAIFunc(model string) (openai.GenerativeAIClient, error) {
-	apikey, err := getAPIKey()
+func makeGAIFunc(model string) (providers.Provider, error) {
+	apikey, err := providerAPIKey(provider)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get API key: %w", err)
 	}
@@ -99,7 +158,61 @@ func makeGAIFunc(model string) (openai.GenerativeAIClient, error) {
 	if c.MaxTokens > 0 {
 		maxTokens = &c.MaxTokens
 	}
-	return openai.New(apikey, model, c.LogAPILevel, maxTokens), nil
+	return providers.New(provider, providers.Config{
+		Model:       model,
+		BaseURL:     baseURL,
+		APIKey:      apikey,
+		LogAPILevel: c.LogAPILevel,
+		MaxTokens:   maxTokens,
+	})
+}
+
+// providerRequiresAPIKey reports whether provider needs an API key to
+// authenticate, which every backend does except a locally hosted Ollama
+// server.
+func providerRequiresAPIKey(provider string) bool {
+	return provider != "ollama"
+}
+
+// providerAPIKeyFilePath resolves the file path holding provider's API key:
+// the --api-key-file flag, then $<PROVIDER>_API_KEY_FILE, then the legacy
+// openai-specific default for backwards compatibility.
+func providerAPIKeyFilePath(provider string) string {
+	if apiKeyFile != "" {
+		return apiKeyFile
+	}
+	if path := os.Getenv(strings.ToUpper(provider) + "_API_KEY_FILE"); path != "" {
+		return path
+	}
+	if provider == "openai" {
+		return getAPIKeyFilePath()
+	}
+	return ""
+}
+
+func checkProviderAPIKeyFileExists(provider string) bool {
+	path := providerAPIKeyFilePath(provider)
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func providerAPIKey(provider string) (string, error) {
+	if !providerRequiresAPIKey(provider) {
+		return "", nil
+	}
+	if provider == "openai" && apiKeyFile == "" {
+		apikey, err := getAPIKey()
+		return string(apikey), err
+	}
+	path := providerAPIKeyFilePath(provider)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
 }
 
 func readInputFiles(fileName string) ([]string, error) {
@@ -120,9 +233,20 @@ func readInputFiles(fileName string) ([]string, error) {
 	return files, nil
 }
 
-func showCosts(usageCosts []*openai.UsageCost) {
+// showCosts prints usageCosts' token-based total alongside audioCost, the
+// USD cost of any Whisper transcriptions, which doesn't fit usageCosts'
+// token-based accounting. audioCost is reported separately rather than
+// folded into a zero-usage entry, so it's never silently misreported as $0.
+func showCosts(usageCosts []*openai.UsageCost, audioCost float64) {
 	totalUsageCost := openai.NewTotalUsageCost(usageCosts)
-	if ok, cost := totalUsageCost.TotalTotalTokensCost(); ok {
+	ok, cost := totalUsageCost.TotalTotalTokensCost()
+
+	if audioCost > 0 {
+		fmt.Printf("Audio transcription cost: $%f\n", audioCost)
+		cost += audioCost
+	}
+
+	if ok || audioCost > 0 {
 		fmt.Printf("Total cost: $%f\n", cost)
 	} else {
 		fmt.Println("Total cost: unknown")
@@ -161,14 +285,14 @@ func createProcessingCallbackFunc(enableTUI bool, rawOnAfterProcessing func(stri
 	return onBeforeProcessing, onAfterProcessing
 }
 
-func doRun(ctx context.Context, inputFiles []string, makeGAIFunc func(model string) (openai.GenerativeAIClient, error)) error {
+func doRun(ctx context.Context, inputFiles []string, makeGAIFunc func(model string) (providers.Provider, error), audioCost float64) error {
 	r := runner.New(&c, inputFiles, makeGAIFunc, tui.Confirm)
 	ropt, err := r.Setup()
 	if err != nil {
 		return fmt.Errorf("failed to setup runner: %w", err)
 	}
 
-	var usageCosts = make([]*openai.UsageCost, 0, len(inputFiles))
+	usageCosts := make([]*openai.UsageCost, 0, len(inputFiles))
 	rawOnAfterProcessing := func(_ string, sr *steps.ShapeResult) {
 		if sr != nil {
 			usageCosts = append(usageCosts, openai.NewUsageCost(sr.ChatCompletion))
@@ -191,7 +315,7 @@ func doRun(ctx context.Context, inputFiles []string, makeGAIFunc func(model stri
 	}
 
 	if c.ShowCost {
-		showCosts(usageCosts)
+		showCosts(usageCosts, audioCost)
 	}
 
 	return nil
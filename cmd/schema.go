@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ytka/textforge/internal/process"
+)
+
+// schemaNameFromPath derives a json_schema name from --schema's file name,
+// since OpenAI requires a short machine-readable identifier alongside the
+// schema body.
+func schemaNameFromPath(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// runStructured drives the --schema path: it requests and validates a
+// JSON-Schema-conforming reply for each input file (or once, if there are
+// none) and prints the raw JSON. It's a direct Shaper path rather than
+// going through the runner pipeline, so unlike the ordinary shaping path it
+// doesn't support --rewrite/--outpath/--diff.
+func runStructured(ctx context.Context, inputFiles []string) error {
+	schema, err := process.LoadSchema(schemaNameFromPath(c.SchemaPath), c.SchemaPath)
+	if err != nil {
+		return err
+	}
+
+	prompt, err := resolvePromptText()
+	if err != nil {
+		return err
+	}
+
+	provider, err := makeGAIFunc(c.Model)
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+	shaper := process.NewShaper(provider, c.MaxCompletionRepeatCount, c.UseFirstCodeBlock, c.PromptOptimize).WithSchema(schema)
+
+	if len(inputFiles) == 0 {
+		return shapeStructuredInput(ctx, shaper, prompt, "")
+	}
+	for _, path := range inputFiles {
+		input, err := readInputFilesContent([]string{path})
+		if err != nil {
+			return err
+		}
+		if err := shapeStructuredInput(ctx, shaper, prompt, input); err != nil {
+			return fmt.Errorf("failed to shape %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// runJSON drives the --json path: it requests a JSON reply for each input
+// file (or once, if there are none) without validating it against a
+// particular schema, and prints it. Like runStructured it's a direct Shaper
+// path, so it doesn't support --rewrite/--outpath/--diff. Has no effect if
+// --schema is also set, since schema mode already implies a JSON reply.
+func runJSON(ctx context.Context, inputFiles []string) error {
+	prompt, err := resolvePromptText()
+	if err != nil {
+		return err
+	}
+
+	provider, err := makeGAIFunc(c.Model)
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+	shaper := process.NewShaper(provider, c.MaxCompletionRepeatCount, c.UseFirstCodeBlock, c.PromptOptimize).WithJSON()
+
+	if len(inputFiles) == 0 {
+		return shapeStructuredInput(ctx, shaper, prompt, "")
+	}
+	for _, path := range inputFiles {
+		input, err := readInputFilesContent([]string{path})
+		if err != nil {
+			return err
+		}
+		if err := shapeStructuredInput(ctx, shaper, prompt, input); err != nil {
+			return fmt.Errorf("failed to shape %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func shapeStructuredInput(ctx context.Context, shaper *process.Shaper, prompt, input string) error {
+	result, err := shaper.ShapeText(ctx, prompt, input)
+	if err != nil {
+		return err
+	}
+	fmt.Print(result.Result)
+	return nil
+}
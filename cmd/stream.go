@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ytka/textforge/internal/process"
+)
+
+// runStream drives the --stream path: it shapes each input file (or once,
+// if there are none) and prints tokens to stdout as they arrive instead of
+// waiting for the full completion, replacing the spinner
+// createProcessingCallbackFunc would otherwise show. Like --agent and
+// --schema, it bypasses the --rewrite/--outpath/--diff-aware runner
+// pipeline.
+func runStream(ctx context.Context, inputFiles []string) error {
+	prompt, err := resolvePromptText()
+	if err != nil {
+		return err
+	}
+
+	provider, err := makeGAIFunc(c.Model)
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+	shaper := process.NewShaper(provider, c.MaxCompletionRepeatCount, c.UseFirstCodeBlock, c.PromptOptimize)
+
+	onChunk := func(chunk string) { fmt.Print(chunk) }
+
+	if len(inputFiles) == 0 {
+		_, err := shaper.ShapeTextStream(ctx, prompt, "", onChunk)
+		return err
+	}
+
+	for _, path := range inputFiles {
+		input, err := readInputFilesContent([]string{path})
+		if err != nil {
+			return err
+		}
+		if _, err := shaper.ShapeTextStream(ctx, prompt, input, onChunk); err != nil {
+			return fmt.Errorf("failed to stream %s: %w", path, err)
+		}
+		fmt.Println()
+	}
+	return nil
+}
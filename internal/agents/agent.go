@@ -0,0 +1,76 @@
+// Package agents defines reusable agent configurations: a system prompt, the
+// tools it's allowed to call, and files pinned into its context for
+// retrieval-augmented prompting.
+package agents
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ytka/textforge/internal/toolbox"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrAgentNotFound is returned by Registry.Get when name isn't defined.
+var ErrAgentNotFound = errors.New("agent not found")
+
+// Agent bundles a system prompt with the tools and pinned files it operates
+// with.
+type Agent struct {
+	Name         string   `yaml:"name"`
+	SystemPrompt string   `yaml:"system_prompt"`
+	Tools        []string `yaml:"tools"`
+	PinnedFiles  []string `yaml:"pinned_files"`
+}
+
+// config is the on-disk shape of an agent definitions file.
+type config struct {
+	Agents []Agent `yaml:"agents"`
+}
+
+// Registry holds the set of agents loaded from a YAML config, keyed by name.
+type Registry struct {
+	agents map[string]Agent
+}
+
+// LoadRegistry reads agent definitions from a YAML file shaped like:
+//
+//	agents:
+//	  - name: reviewer
+//	    system_prompt: "..."
+//	    tools: [read_file, list_dir]
+func LoadRegistry(data []byte) (*Registry, error) {
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent config: %w", err)
+	}
+
+	r := &Registry{agents: make(map[string]Agent, len(cfg.Agents))}
+	for _, a := range cfg.Agents {
+		r.agents[a.Name] = a
+	}
+	return r, nil
+}
+
+// Get looks up an agent by name.
+func (r *Registry) Get(name string) (Agent, error) {
+	a, ok := r.agents[name]
+	if !ok {
+		return Agent{}, fmt.Errorf("%w: %s", ErrAgentNotFound, name)
+	}
+	return a, nil
+}
+
+// Toolbox builds the toolbox.Registry containing only the tools this agent
+// is allowed to call, looked up by name from all.
+func (a Agent) Toolbox(all *toolbox.Registry) (*toolbox.Registry, error) {
+	tools := make([]toolbox.Tool, 0, len(a.Tools))
+	for _, name := range a.Tools {
+		t, err := all.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("agent %s: %w", a.Name, err)
+		}
+		tools = append(tools, t)
+	}
+	return toolbox.NewRegistry(tools...), nil
+}
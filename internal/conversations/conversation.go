@@ -0,0 +1,66 @@
+package conversations
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// NewConversation creates an empty conversation titled title.
+func (s *Store) NewConversation(title string) (*Conversation, error) {
+	res, err := s.db.Exec(`INSERT INTO conversations (title) VALUES (?)`, title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conversation: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read new conversation id: %w", err)
+	}
+	return s.GetConversation(id)
+}
+
+// GetConversation fetches a conversation by id.
+func (s *Store) GetConversation(id int64) (*Conversation, error) {
+	var c Conversation
+	err := s.db.QueryRow(`SELECT id, title, created_at FROM conversations WHERE id = ?`, id).
+		Scan(&c.ID, &c.Title, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: conversation %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation %d: %w", id, err)
+	}
+	return &c, nil
+}
+
+// ListConversations returns every conversation, most recently created first.
+func (s *Store) ListConversations() ([]Conversation, error) {
+	rows, err := s.db.Query(`SELECT id, title, created_at FROM conversations ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []Conversation
+	for rows.Next() {
+		var c Conversation
+		if err := rows.Scan(&c.ID, &c.Title, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan conversation: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// DeleteConversation removes a conversation and all of its messages.
+func (s *Store) DeleteConversation(id int64) error {
+	if _, err := s.db.Exec(`DELETE FROM messages WHERE conversation_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete messages for conversation %d: %w", id, err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM conversations WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete conversation %d: %w", id, err)
+	}
+	return nil
+}
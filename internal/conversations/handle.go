@@ -0,0 +1,28 @@
+package conversations
+
+import "errors"
+
+// Handle is a cursor into one conversation: the branch to continue from.
+// LeafID is nil until the first message is appended, and advances to the
+// latest assistant message after each reply.
+type Handle struct {
+	Store          *Store
+	ConversationID int64
+	LeafID         *int64
+}
+
+// NewHandle positions a Handle at the conversation's current latest
+// message, ready to reply. It's fine for a brand-new conversation to have
+// no messages yet; LeafID is left nil.
+func NewHandle(store *Store, conversationID int64) (*Handle, error) {
+	h := &Handle{Store: store, ConversationID: conversationID}
+	latest, err := store.LatestMessage(conversationID)
+	if errors.Is(err, ErrNotFound) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	h.LeafID = &latest.ID
+	return h, nil
+}
@@ -0,0 +1,139 @@
+package conversations
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// AppendMessage adds a message as a child of parentID (nil for the first
+// message of a conversation) and returns the stored row.
+func (s *Store) AppendMessage(conversationID int64, parentID *int64, role, content, model string, promptTokens, completionTokens int) (*Message, error) {
+	res, err := s.db.Exec(
+		`INSERT INTO messages (conversation_id, parent_id, role, content, model, prompt_tokens, completion_tokens)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		conversationID, parentID, role, content, model, promptTokens, completionTokens,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append message: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read new message id: %w", err)
+	}
+	return s.GetMessage(id)
+}
+
+// GetMessage fetches a single message by id.
+func (s *Store) GetMessage(id int64) (*Message, error) {
+	var m Message
+	var parentID sql.NullInt64
+	err := s.db.QueryRow(
+		`SELECT id, conversation_id, parent_id, role, content, model, prompt_tokens, completion_tokens, created_at
+		 FROM messages WHERE id = ?`, id,
+	).Scan(&m.ID, &m.ConversationID, &parentID, &m.Role, &m.Content, &m.Model, &m.PromptTokens, &m.CompletionTokens, &m.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: message %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message %d: %w", id, err)
+	}
+	if parentID.Valid {
+		m.ParentID = &parentID.Int64
+	}
+	return &m, nil
+}
+
+// LatestMessage returns the most recently created message in a conversation,
+// used as the branch leaf for `reply`, and for `view` when no leaf is given.
+func (s *Store) LatestMessage(conversationID int64) (*Message, error) {
+	var id int64
+	err := s.db.QueryRow(
+		`SELECT id FROM messages WHERE conversation_id = ? ORDER BY id DESC LIMIT 1`, conversationID,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: conversation %d has no messages", ErrNotFound, conversationID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest message for conversation %d: %w", conversationID, err)
+	}
+	return s.GetMessage(id)
+}
+
+// Branch walks from leafID up to the conversation's root via parent_id and
+// returns the messages in root-to-leaf order.
+func (s *Store) Branch(leafID int64) ([]Message, error) {
+	var branch []Message
+
+	id := &leafID
+	for id != nil {
+		m, err := s.GetMessage(*id)
+		if err != nil {
+			return nil, err
+		}
+		branch = append(branch, *m)
+		id = m.ParentID
+	}
+
+	for i, j := 0, len(branch)-1; i < j; i, j = i+1, j-1 {
+		branch[i], branch[j] = branch[j], branch[i]
+	}
+	return branch, nil
+}
+
+// Edit creates a new sibling of editedMessageID with content, branching the
+// conversation: the new message shares editedMessageID's parent rather than
+// replacing it, so the original turn remains reachable as its own branch.
+func (s *Store) Edit(editedMessageID int64, content string) (*Message, error) {
+	original, err := s.GetMessage(editedMessageID)
+	if err != nil {
+		return nil, err
+	}
+	return s.AppendMessage(original.ConversationID, original.ParentID, original.Role, content, original.Model, 0, 0)
+}
+
+// TotalUsage sums token usage across every message in a conversation.
+func (s *Store) TotalUsage(conversationID int64) (promptTokens, completionTokens int, err error) {
+	err = s.db.QueryRow(
+		`SELECT COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0)
+		 FROM messages WHERE conversation_id = ?`, conversationID,
+	).Scan(&promptTokens, &completionTokens)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to total usage for conversation %d: %w", conversationID, err)
+	}
+	return promptTokens, completionTokens, nil
+}
+
+// ModelUsage is one model's aggregated token usage within a conversation.
+type ModelUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// UsageByModel sums token usage per model across every message in a
+// conversation. Unlike TotalUsage, it keeps usage split by model, since
+// cost is priced per model and a conversation can span more than one if
+// --model changes between replies.
+func (s *Store) UsageByModel(conversationID int64) (map[string]ModelUsage, error) {
+	rows, err := s.db.Query(
+		`SELECT model, COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0)
+		 FROM messages WHERE conversation_id = ? AND model != '' GROUP BY model`, conversationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate usage by model for conversation %d: %w", conversationID, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	usage := make(map[string]ModelUsage)
+	for rows.Next() {
+		var model string
+		var u ModelUsage
+		if err := rows.Scan(&model, &u.PromptTokens, &u.CompletionTokens); err != nil {
+			return nil, fmt.Errorf("failed to scan usage by model: %w", err)
+		}
+		usage[model] = u
+	}
+	return usage, rows.Err()
+}
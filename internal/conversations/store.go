@@ -0,0 +1,93 @@
+// Package conversations persists multi-turn conversations as a tree of
+// messages (not a flat list), so that editing an earlier turn branches off a
+// new line of history instead of overwriting it.
+package conversations
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// ErrNotFound is returned when a conversation or message id doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// Conversation is a named root for a tree of Messages.
+type Conversation struct {
+	ID        int64
+	Title     string
+	CreatedAt time.Time
+}
+
+// Message is one node in a conversation's tree. ParentID is nil for the
+// first message of a conversation.
+type Message struct {
+	ID               int64
+	ConversationID   int64
+	ParentID         *int64
+	Role             string
+	Content          string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	CreatedAt        time.Time
+}
+
+// Store is a SQLite-backed conversation tree store. SQLite is accessed
+// through modernc.org/sqlite so textforge stays CGO-free.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and
+// ensures its schema is up to date.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open conversation store: %w", err)
+	}
+
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close releases the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate() error {
+	_, err := s.db.Exec(`
+CREATE TABLE IF NOT EXISTS conversations (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	title      TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+	id                INTEGER PRIMARY KEY AUTOINCREMENT,
+	conversation_id   INTEGER NOT NULL REFERENCES conversations(id),
+	parent_id         INTEGER REFERENCES messages(id),
+	role              TEXT NOT NULL,
+	content           TEXT NOT NULL,
+	model             TEXT NOT NULL DEFAULT '',
+	prompt_tokens     INTEGER NOT NULL DEFAULT 0,
+	completion_tokens INTEGER NOT NULL DEFAULT 0,
+	created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS messages_conversation_id_idx ON messages(conversation_id);
+CREATE INDEX IF NOT EXISTS messages_parent_id_idx ON messages(parent_id);
+`)
+	if err != nil {
+		return fmt.Errorf("failed to migrate conversation store: %w", err)
+	}
+	return nil
+}
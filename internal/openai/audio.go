@@ -0,0 +1,112 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// whisperModel is the only transcription model OpenAI currently offers.
+const whisperModel = "whisper-1"
+
+// whisperCostPerMinute is Whisper's per-minute transcription price in USD.
+const whisperCostPerMinute = 0.006
+
+// AudioClient wraps OpenAI's audio transcription endpoint.
+type AudioClient struct {
+	apikey   APIKey
+	logLevel string
+}
+
+// NewAudioClient creates a new AudioClient instance.
+func NewAudioClient(apikey APIKey, logLevel string) *AudioClient {
+	return &AudioClient{apikey: apikey, logLevel: logLevel}
+}
+
+// TranscriptionResponse is the body of a successful transcription request.
+type TranscriptionResponse struct {
+	Text     string  `json:"text"`
+	Duration float64 `json:"duration"`
+}
+
+// Cost estimates the USD cost of this transcription from its reported
+// duration, since Whisper bills per minute of audio rather than per token.
+func (tr *TranscriptionResponse) Cost() float64 {
+	return tr.Duration / 60 * whisperCostPerMinute
+}
+
+// Transcribe uploads the audio file at path to
+// POST /v1/audio/transcriptions and returns the resulting transcript text.
+func (c *AudioClient) Transcribe(ctx context.Context, path string) (*TranscriptionResponse, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio file %s: %w", path, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var requestBody bytes.Buffer
+	writer := multipart.NewWriter(&requestBody)
+
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multipart file field: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("failed to copy audio file into request: %w", err)
+	}
+	if err := writer.WriteField("model", whisperModel); err != nil {
+		return nil, fmt.Errorf("failed to write model field: %w", err)
+	}
+	if err := writer.WriteField("response_format", "verbose_json"); err != nil {
+		return nil, fmt.Errorf("failed to write response_format field: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/audio/transcriptions", &requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apikey))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if c.logLevel == "debug" {
+		fmt.Printf("transcriptionResponseBody: %s\n", respBody)
+	}
+
+	if resp.StatusCode > 299 {
+		var errorResponse ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResponse); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal error response: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %d '%s'", ErrUnexpectedStatusCode, resp.StatusCode, errorResponse.Error.Message)
+	}
+
+	var tr TranscriptionResponse
+	if err := json.Unmarshal(respBody, &tr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transcription response: %w", err)
+	}
+	return &tr, nil
+}
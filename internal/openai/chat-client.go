@@ -1,6 +1,7 @@
 package openai
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -8,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
 // ErrUnexpectedStatusCode is an error for unexpected status code.
@@ -21,8 +23,6 @@ type ChatClient struct {
 	maxTokens *int
 }
 
-var _ GenerativeAIClient = (*ChatClient)(nil)
-
 // New creates a new ChatClient instance.
 func New(apikey APIKey, model, logLevel string, maxTokens *int) *ChatClient {
 	return &ChatClient{
@@ -38,6 +38,12 @@ func (c *ChatClient) MakeCreateChatCompletion(prompt string) *CreateChatCompleti
 	return newCreateChatCompletion(c.model, prompt, c.maxTokens, false)
 }
 
+// MakeStreamingCreateChatCompletion creates a new CreateChatCompletion with
+// SSE streaming enabled.
+func (c *ChatClient) MakeStreamingCreateChatCompletion(prompt string) *CreateChatCompletion {
+	return newCreateChatCompletion(c.model, prompt, c.maxTokens, true)
+}
+
 // sendChatCompletionsRequest sends a request to the chat completions endpoint.
 func (c *ChatClient) sendChatCompletionsRequest(ctx context.Context, ccc *CreateChatCompletion) (*http.Response, error) {
 	requestBody, err := json.Marshal(ccc)
@@ -114,3 +120,81 @@ func (c *ChatClient) RequestCreateChatCompletion(ctx context.Context, ccc *Creat
 
 	return c.makeChatCompletions(respBody)
 }
+
+// chatCompletionChunk is one `data: {...}` event of an SSE chat completion
+// stream, in OpenAI's delta-based schema.
+type chatCompletionChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+		Index        int    `json:"index"`
+	} `json:"choices"`
+}
+
+// RequestCreateChatCompletionStream behaves like RequestCreateChatCompletion
+// but reads the response as an SSE stream, invoking onChunk with each piece
+// of content as it arrives. The returned ChatCompletion carries the model,
+// the fully assembled message and its finish reason; its Usage and ID fields
+// are left empty.
+func (c *ChatClient) RequestCreateChatCompletionStream(ctx context.Context, ccc *CreateChatCompletion, onChunk func(string)) (*ChatCompletion, error) {
+	resp, err := c.sendChatCompletionsRequest(ctx, ccc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			fmt.Printf("failed to close response body: %s\n", cerr)
+		}
+	}()
+
+	if resp.StatusCode > 299 {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		var errorResponse ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResponse); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal error response: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %d '%s'", ErrUnexpectedStatusCode, resp.StatusCode, errorResponse.Error.Message)
+	}
+
+	var content strings.Builder
+	var finishReason string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		payload, ok := strings.CutPrefix(line, "data: ")
+		if !ok || payload == "[DONE]" {
+			continue
+		}
+
+		var chunk chatCompletionChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal stream chunk: %w", err)
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		if delta := chunk.Choices[0].Delta.Content; delta != "" {
+			content.WriteString(delta)
+			onChunk(delta)
+		}
+		if reason := chunk.Choices[0].FinishReason; reason != "" {
+			finishReason = reason
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	return &ChatCompletion{
+		Model: ccc.Model,
+		Choices: []Choice{
+			{Message: ChatMessage{Role: "assistant", Content: content.String()}, FinishReason: finishReason},
+		},
+	}, nil
+}
@@ -0,0 +1,23 @@
+package openai
+
+// chatModelPricing is the USD cost per million tokens for the chat models
+// textforge defaults to, used to estimate cost from stored token counts
+// (e.g. a conversation's history) rather than a live response's usage.
+var chatModelPricing = map[string]struct {
+	PromptPerMillion     float64
+	CompletionPerMillion float64
+}{
+	"gpt-4o":      {PromptPerMillion: 5.00, CompletionPerMillion: 15.00},
+	"gpt-4o-mini": {PromptPerMillion: 0.15, CompletionPerMillion: 0.60},
+}
+
+// ChatCompletionCost estimates the USD cost of a chat completion from its
+// model and token counts. ok is false if model isn't in chatModelPricing.
+func ChatCompletionCost(model string, promptTokens, completionTokens int) (cost float64, ok bool) {
+	price, found := chatModelPricing[model]
+	if !found {
+		return 0, false
+	}
+	cost = float64(promptTokens)/1_000_000*price.PromptPerMillion + float64(completionTokens)/1_000_000*price.CompletionPerMillion
+	return cost, true
+}
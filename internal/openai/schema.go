@@ -0,0 +1,187 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// schemaRequestBody is the full wire-format body for a structured-output
+// chat completion request. It's built independently of CreateChatCompletion
+// so that response_format's json_schema nesting doesn't have to be
+// retrofitted onto the plain-text request path.
+type schemaRequestBody struct {
+	Model          string               `json:"model"`
+	Messages       []ChatMessage        `json:"messages"`
+	MaxTokens      *int                 `json:"max_tokens,omitempty"`
+	ResponseFormat schemaResponseFormat `json:"response_format"`
+}
+
+type schemaResponseFormat struct {
+	Type       string     `json:"type"`
+	JSONSchema jsonSchema `json:"json_schema"`
+}
+
+type jsonSchema struct {
+	Name   string         `json:"name"`
+	Schema map[string]any `json:"schema"`
+	Strict bool           `json:"strict"`
+}
+
+// jsonModeRequestBody is like schemaRequestBody, but response_format has no
+// schema nested under it: the reply only has to be valid JSON, not match
+// any particular shape.
+type jsonModeRequestBody struct {
+	Model          string                 `json:"model"`
+	Messages       []ChatMessage          `json:"messages"`
+	MaxTokens      *int                   `json:"max_tokens,omitempty"`
+	ResponseFormat jsonModeResponseFormat `json:"response_format"`
+}
+
+type jsonModeResponseFormat struct {
+	Type string `json:"type"`
+}
+
+// RequestCreateChatCompletionWithSchema sends messages with response_format
+// set to json_schema, constraining the model's reply to the given schema.
+// The reply is returned unparsed; validating it is process.Schema's job.
+func (c *ChatClient) RequestCreateChatCompletionWithSchema(ctx context.Context, messages []ChatMessage, schemaName string, schema map[string]any) (*ToolAwareResult, error) {
+	body := schemaRequestBody{
+		Model:     c.model,
+		Messages:  messages,
+		MaxTokens: c.maxTokens,
+		ResponseFormat: schemaResponseFormat{
+			Type: "json_schema",
+			JSONSchema: jsonSchema{
+				Name:   schemaName,
+				Schema: schema,
+				Strict: true,
+			},
+		},
+	}
+
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	if c.logLevel == "debug" {
+		fmt.Printf("createChatCompletionWithSchema: %s\n", requestBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apikey))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode > 299 {
+		var errorResponse ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResponse); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal error response: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %d '%s'", ErrUnexpectedStatusCode, resp.StatusCode, errorResponse.Error.Message)
+	}
+
+	var comp toolAwareChatCompletion
+	if err := json.Unmarshal(respBody, &comp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	result := &ToolAwareResult{
+		Usage: UsageStats{
+			PromptTokens:     comp.Usage.PromptTokens,
+			CompletionTokens: comp.Usage.CompletionTokens,
+			TotalTokens:      comp.Usage.TotalTokens,
+		},
+	}
+	if len(comp.Choices) > 0 {
+		result.Content = comp.Choices[0].Message.Content
+		result.FinishReason = comp.Choices[0].FinishReason
+	}
+	return result, nil
+}
+
+// RequestCreateChatCompletionJSON sends messages with response_format set
+// to json_object, requesting a JSON reply without constraining it to any
+// particular schema. Unlike RequestCreateChatCompletionWithSchema, the
+// reply isn't validated against anything; --json trades that guarantee for
+// not needing a schema file.
+func (c *ChatClient) RequestCreateChatCompletionJSON(ctx context.Context, messages []ChatMessage) (*ToolAwareResult, error) {
+	body := jsonModeRequestBody{
+		Model:          c.model,
+		Messages:       messages,
+		MaxTokens:      c.maxTokens,
+		ResponseFormat: jsonModeResponseFormat{Type: "json_object"},
+	}
+
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	if c.logLevel == "debug" {
+		fmt.Printf("createChatCompletionJSON: %s\n", requestBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apikey))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode > 299 {
+		var errorResponse ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResponse); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal error response: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %d '%s'", ErrUnexpectedStatusCode, resp.StatusCode, errorResponse.Error.Message)
+	}
+
+	var comp toolAwareChatCompletion
+	if err := json.Unmarshal(respBody, &comp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	result := &ToolAwareResult{
+		Usage: UsageStats{
+			PromptTokens:     comp.Usage.PromptTokens,
+			CompletionTokens: comp.Usage.CompletionTokens,
+			TotalTokens:      comp.Usage.TotalTokens,
+		},
+	}
+	if len(comp.Choices) > 0 {
+		result.Content = comp.Choices[0].Message.Content
+		result.FinishReason = comp.Choices[0].FinishReason
+	}
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ChatCompletionTool describes a single callable function in OpenAI's
+// `tools` request schema.
+type ChatCompletionTool struct {
+	Type     string                 `json:"type"`
+	Function ChatCompletionFunction `json:"function"`
+}
+
+// ChatCompletionFunction is the function a ChatCompletionTool exposes.
+type ChatCompletionFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters"`
+}
+
+// ToolCall is a single function call the model asked for in place of a
+// final assistant message.
+type ToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// MakeCreateChatCompletionWithTools builds a CreateChatCompletion carrying
+// the full message history (rather than a single prompt) plus the set of
+// tools the model may call, for use by the agentic loop in process.Shaper.
+func (c *ChatClient) MakeCreateChatCompletionWithTools(messages []ChatMessage, tools []ChatCompletionTool) *CreateChatCompletion {
+	ccc := newCreateChatCompletion(c.model, "", c.maxTokens, false)
+	ccc.Messages = messages
+	ccc.Tools = tools
+	return ccc
+}
+
+// ToolAwareResult is the reply to a tool-enabled request: Content holds the
+// assistant message text and ToolCalls the tools the model wants to call,
+// both copied from the first choice as returned.
+type ToolAwareResult struct {
+	Content      string
+	FinishReason string
+	ToolCalls    []ToolCall
+	Usage        UsageStats
+}
+
+// UsageStats mirrors OpenAI's token accounting for a single request.
+type UsageStats struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// toolAwareChatCompletion is parsed independently of ChatCompletion so that
+// tool-call support doesn't depend on that type also carrying tool_calls.
+type toolAwareChatCompletion struct {
+	Choices []struct {
+		Message struct {
+			Content   string     `json:"content"`
+			ToolCalls []ToolCall `json:"tool_calls"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// RequestCreateChatCompletionWithTools sends ccc (built with
+// MakeCreateChatCompletionWithTools) and returns the model's reply, which is
+// either a final message or a set of tool calls to dispatch.
+func (c *ChatClient) RequestCreateChatCompletionWithTools(ctx context.Context, ccc *CreateChatCompletion) (*ToolAwareResult, error) {
+	resp, err := c.sendChatCompletionsRequest(ctx, ccc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			fmt.Printf("failed to close response body: %s\n", cerr)
+		}
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode > 299 {
+		var errorResponse ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResponse); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal error response: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %d '%s'", ErrUnexpectedStatusCode, resp.StatusCode, errorResponse.Error.Message)
+	}
+
+	var comp toolAwareChatCompletion
+	if err := json.Unmarshal(respBody, &comp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	result := &ToolAwareResult{
+		Usage: UsageStats{
+			PromptTokens:     comp.Usage.PromptTokens,
+			CompletionTokens: comp.Usage.CompletionTokens,
+			TotalTokens:      comp.Usage.TotalTokens,
+		},
+	}
+	if len(comp.Choices) > 0 {
+		choice := comp.Choices[0]
+		result.Content = choice.Message.Content
+		result.FinishReason = choice.FinishReason
+		result.ToolCalls = choice.Message.ToolCalls
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ytka/textforge/internal/agents"
+	"github.com/ytka/textforge/internal/providers"
+	"github.com/ytka/textforge/internal/toolbox"
+)
+
+// ErrToolCallDeclined is returned when the user declines a tool call the
+// model asked for.
+var ErrToolCallDeclined = fmt.Errorf("tool call declined")
+
+// ErrAgentIterationsExceeded is returned when the model still wants to call
+// a tool after maxAgentIterations round trips instead of converging on a
+// final answer.
+var ErrAgentIterationsExceeded = errors.New("agent exceeded max tool-call iterations without producing a final answer")
+
+// maxAgentIterations bounds how many tool-call round trips ShapeWithAgent
+// makes before giving up. This is deliberately separate from
+// maxCompletionRepeatCount, which bounds truncation-continuation retries
+// (defaults to 1) and would otherwise cut the loop off before the model
+// ever got to finish a single tool call.
+const maxAgentIterations = 25
+
+// ShapeWithAgent drives the agentic loop: it sends promptOrg (plus inputOrg,
+// optimized the same way ShapeText does) to the model alongside tools, and
+// whenever the model's FinishReason is providers.FinishReasonToolCalls it
+// dispatches each call through toolRegistry, feeds the result back as a
+// "tool" message, and re-requests. The loop runs until the model produces a
+// final (non-tool-call) reply or maxAgentIterations round trips are spent,
+// in which case it returns ErrAgentIterationsExceeded rather than an empty
+// result. confirm is asked before every tool call; returning false
+// short-circuits the loop with ErrToolCallDeclined.
+func (s *Shaper) ShapeWithAgent(ctx context.Context, agent agents.Agent, toolRegistry *toolbox.Registry, promptOrg, inputOrg string, confirm func(toolName, argsJSON string) bool) (*ShapeResult, error) {
+	prompt := promptOrg
+	if inputOrg != "" || s.promptOptimize {
+		prompt = optimizePrompt(promptOrg, inputOrg)
+	}
+
+	messages := []providers.Message{}
+	if agent.SystemPrompt != "" {
+		messages = append(messages, providers.Message{Role: "system", Content: agent.SystemPrompt})
+	}
+	pinned, err := pinnedFilesContext(agent.PinnedFiles)
+	if err != nil {
+		return nil, err
+	}
+	if pinned != "" {
+		messages = append(messages, providers.Message{Role: "system", Content: pinned})
+	}
+	messages = append(messages, providers.Message{Role: "user", Content: prompt})
+
+	tools := toolDefinitions(toolRegistry)
+
+	var rawResult string
+	converged := false
+	for i := 0; i < maxAgentIterations; i++ {
+		resp, err := s.provider.CreateChatCompletion(ctx, &providers.Request{Messages: messages, Tools: tools})
+		if err != nil {
+			return nil, fmt.Errorf("failed to send chat message: %w", err)
+		}
+
+		if resp.FinishReason != providers.FinishReasonToolCalls {
+			rawResult = resp.Content
+			converged = true
+			break
+		}
+
+		messages = append(messages, providers.Message{Role: "assistant", Content: resp.Content})
+		for _, call := range resp.ToolCalls {
+			if confirm != nil && !confirm(call.ToolName, call.Arguments) {
+				return nil, fmt.Errorf("%w: %s", ErrToolCallDeclined, call.ToolName)
+			}
+
+			result, err := dispatchToolCall(ctx, toolRegistry, call)
+			if err != nil {
+				result = fmt.Sprintf("error: %s", err)
+			}
+			messages = append(messages, providers.Message{Role: "tool", Content: result, ToolCallID: call.ID})
+		}
+	}
+	if !converged {
+		return nil, fmt.Errorf("%w (limit %d)", ErrAgentIterationsExceeded, maxAgentIterations)
+	}
+
+	result, err := optimizeResponseResult(rawResult, s.useFirstCodeBlock)
+	if err != nil {
+		return nil, err
+	}
+	return NewShapeResult(prompt, rawResult, result), nil
+}
+
+// pinnedFilesContext reads every path in paths and renders them as a single
+// system message giving the model RAG-style reference context, in addition
+// to whatever it reads back via the read_file tool. Returns "" if paths is
+// empty.
+func pinnedFilesContext(paths []string) (string, error) {
+	if len(paths) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("The following files are pinned as reference context:\n")
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read pinned file %s: %w", path, err)
+		}
+		fmt.Fprintf(&sb, "\n--- %s ---\n%s\n", path, data)
+	}
+	return sb.String(), nil
+}
+
+func dispatchToolCall(ctx context.Context, registry *toolbox.Registry, call providers.ToolCall) (string, error) {
+	tool, err := registry.Get(call.ToolName)
+	if err != nil {
+		return "", err
+	}
+	return tool.Execute(ctx, call.Arguments)
+}
+
+func toolDefinitions(registry *toolbox.Registry) []providers.ToolDefinition {
+	tools := registry.All()
+	defs := make([]providers.ToolDefinition, 0, len(tools))
+	for _, t := range tools {
+		defs = append(defs, providers.ToolDefinition{Name: t.Name(), Description: t.Description(), Parameters: t.Parameters()})
+	}
+	return defs
+}
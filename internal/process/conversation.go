@@ -0,0 +1,63 @@
+package process
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ytka/textforge/internal/conversations"
+	"github.com/ytka/textforge/internal/providers"
+)
+
+// ShapeTextInConversation appends promptOrg (and inputOrg, optimized the
+// same way ShapeText does, but only on the conversation's first turn) to
+// conv's current branch, sends the whole branch to the model, and appends
+// the reply as a new leaf. Unlike ShapeText it's not one-shot: conv.LeafID
+// advances after every call, so the next call continues the same thread.
+// model is recorded alongside each stored message so that a conversation's
+// cost can later be computed per model, even if the model changes partway
+// through.
+func (s *Shaper) ShapeTextInConversation(ctx context.Context, conv *conversations.Handle, promptOrg, inputOrg, model string) (*ShapeResult, error) {
+	prompt := promptOrg
+	if conv.LeafID == nil && (inputOrg != "" || s.promptOptimize) {
+		prompt = optimizePrompt(promptOrg, inputOrg)
+	}
+
+	userMsg, err := conv.Store.AppendMessage(conv.ConversationID, conv.LeafID, "user", prompt, model, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append user message: %w", err)
+	}
+	conv.LeafID = &userMsg.ID
+
+	branch, err := conv.Store.Branch(userMsg.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conversation branch: %w", err)
+	}
+
+	rawResult, usage, err := s.requestCreateChatCompletion(ctx, branchToMessages(branch))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := optimizeResponseResult(rawResult, s.useFirstCodeBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	assistantMsg, err := conv.Store.AppendMessage(conv.ConversationID, conv.LeafID, "assistant", rawResult, model, usage.PromptTokens, usage.CompletionTokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append assistant message: %w", err)
+	}
+	conv.LeafID = &assistantMsg.ID
+
+	sr := NewShapeResult(prompt, rawResult, result)
+	sr.Usage = usage
+	return sr, nil
+}
+
+func branchToMessages(branch []conversations.Message) []providers.Message {
+	messages := make([]providers.Message, 0, len(branch))
+	for _, m := range branch {
+		messages = append(messages, providers.Message{Role: m.Role, Content: m.Content})
+	}
+	return messages
+}
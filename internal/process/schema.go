@@ -0,0 +1,111 @@
+package process
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/ytka/textforge/internal/providers"
+)
+
+// Schema is a compiled JSON Schema used to request and validate structured
+// output via a provider's json_schema response_format mode.
+type Schema struct {
+	Name     string
+	Raw      map[string]any
+	compiled *jsonschema.Schema
+}
+
+// LoadSchema reads and compiles the JSON Schema file at path. name
+// identifies the schema in the request (response_format's json_schema.name
+// must be a short machine-readable identifier).
+func LoadSchema(name, path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file %s: %w", path, err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse schema file %s: %w", path, err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(path, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to add schema resource %s: %w", path, err)
+	}
+	compiled, err := compiler.Compile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %s: %w", path, err)
+	}
+
+	return &Schema{Name: name, Raw: raw, compiled: compiled}, nil
+}
+
+// Format translates the schema into provider-agnostic request form.
+func (s *Schema) Format() *providers.SchemaFormat {
+	return &providers.SchemaFormat{Name: s.Name, Schema: s.Raw}
+}
+
+// Validate parses raw as JSON and validates it against the schema,
+// returning the decoded value on success.
+func (s *Schema) Validate(raw string) (any, error) {
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, fmt.Errorf("response is not valid JSON: %w", err)
+	}
+	if err := s.compiled.Validate(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// WithSchema enables schema-validated structured output: ShapeText requests
+// response_format: json_schema and retries up to maxSchemaRetryCount times,
+// feeding the validation errors back as a corrective system message, if the
+// reply doesn't conform.
+func (s *Shaper) WithSchema(schema *Schema) *Shaper {
+	s.schema = schema
+	return s
+}
+
+// maxSchemaRetryCount bounds how many times requestCreateChatCompletionWithSchema
+// retries a schema-mode request after a validation failure.
+const maxSchemaRetryCount = 3
+
+// requestCreateChatCompletionWithSchema behaves like
+// requestCreateChatCompletion, but validates the reply against s.schema and
+// retries with the validation errors appended as a corrective system message
+// when it doesn't conform.
+func (s *Shaper) requestCreateChatCompletionWithSchema(ctx context.Context, messages []providers.Message) (string, any, providers.Usage, error) {
+	var totalUsage providers.Usage
+
+	for attempt := 0; attempt <= maxSchemaRetryCount; attempt++ {
+		rawResult, usage, err := s.requestCreateChatCompletion(ctx, messages)
+		totalUsage.PromptTokens += usage.PromptTokens
+		totalUsage.CompletionTokens += usage.CompletionTokens
+		totalUsage.TotalTokens += usage.TotalTokens
+		if err != nil {
+			return "", nil, totalUsage, err
+		}
+
+		parsed, validationErr := s.schema.Validate(rawResult)
+		if validationErr == nil {
+			return rawResult, parsed, totalUsage, nil
+		}
+		if attempt == maxSchemaRetryCount {
+			return "", nil, totalUsage, fmt.Errorf("response did not satisfy schema %q after %d attempts: %w", s.schema.Name, maxSchemaRetryCount+1, validationErr)
+		}
+
+		messages = append(messages,
+			providers.Message{Role: "assistant", Content: rawResult},
+			providers.Message{Role: "system", Content: fmt.Sprintf("Your last reply did not satisfy the required JSON schema: %s. Reply again with corrected JSON only.", validationErr)},
+		)
+	}
+
+	return "", nil, totalUsage, fmt.Errorf("response did not satisfy schema %q", s.schema.Name)
+}
@@ -1,21 +1,22 @@
 package process
 
 import (
-	"ai-text-shaper/internal/openai"
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
-)
 
-type GenerativeAIClient interface {
-	RequestCreateChatCompletion(*openai.CreateChatCompletion) (*openai.ChatCompletion, error)
-	MakeCreateChatCompletion(prompt string) *openai.CreateChatCompletion
-}
+	"github.com/ytka/textforge/internal/providers"
+)
 
 type ShapeResult struct {
 	Prompt    string
 	RawResult string
 	Result    string
+	Usage     providers.Usage
+	// JSON is the decoded structured output when the Shaper has a Schema
+	// set via WithSchema, and nil otherwise.
+	JSON any
 }
 
 func NewShapeResult(prompt, rawResult, result string) *ShapeResult {
@@ -26,19 +27,118 @@ func NewShapeResult(prompt, rawResult, result string) *ShapeResult {
 }
 
 type Shaper struct {
-	gai                      GenerativeAIClient
+	provider                 providers.Provider
 	maxCompletionRepeatCount int
 	useFirstCodeBlock        bool
 	promptOptimize           bool
+	schema                   *Schema
+	json                     bool
+}
+
+// WithJSON enables schema-less JSON mode: requests are sent with a
+// response_format that only requires the reply to be valid JSON, without
+// the validation (or retries) WithSchema performs against a particular
+// shape. Has no effect once WithSchema is also set; schema mode already
+// implies a JSON reply.
+func (s *Shaper) WithJSON() *Shaper {
+	s.json = true
+	return s
+}
+
+func NewShaper(provider providers.Provider, maxCompletionRepeatCount int, useFirstCodeBlock bool, promptOptimize bool) *Shaper {
+	return &Shaper{provider: provider, maxCompletionRepeatCount: maxCompletionRepeatCount, useFirstCodeBlock: useFirstCodeBlock, promptOptimize: promptOptimize}
+}
+
+func (s *Shaper) ShapeText(ctx context.Context, promptOrg, inputOrg string) (*ShapeResult, error) {
+	optimize := inputOrg != "" || s.promptOptimize
+	prompt := promptOrg
+	if optimize {
+		prompt = optimizePrompt(promptOrg, inputOrg)
+	}
+	messages := []providers.Message{{Role: "user", Content: prompt}}
+
+	if s.schema != nil {
+		rawResult, parsed, usage, err := s.requestCreateChatCompletionWithSchema(ctx, messages)
+		if err != nil {
+			return nil, err
+		}
+		sr := NewShapeResult(prompt, rawResult, rawResult)
+		sr.Usage = usage
+		sr.JSON = parsed
+		return sr, nil
+	}
+
+	rawResult, usage, err := s.requestCreateChatCompletion(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+	result := rawResult
+	if optimize {
+		result, err = optimizeResponseResult(rawResult, s.useFirstCodeBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sr := NewShapeResult(prompt, rawResult, result)
+	sr.Usage = usage
+	return sr, nil
 }
 
-func NewShaper(gai GenerativeAIClient, maxCompletionRepeatCount int, useFirstCodeBlock bool, promptOptimize bool) *Shaper {
-	return &Shaper{gai: gai, maxCompletionRepeatCount: maxCompletionRepeatCount, useFirstCodeBlock: useFirstCodeBlock, promptOptimize: promptOptimize}
+// continuationNudge is appended as a system message whenever the model's
+// previous reply was cut off by the token limit, asking it to pick up
+// exactly where it left off instead of restarting.
+const continuationNudge = "Continue exactly where you left off; do not repeat prior content."
+
+// requestCreateChatCompletion sends messages and, if the model's reply hits
+// the token limit (FinishReason == "length"), re-requests up to
+// maxCompletionRepeatCount times, feeding the partial reply back as an
+// assistant turn plus continuationNudge and concatenating every reply's
+// content. Usage is summed across all of the requests this makes.
+func (s *Shaper) requestCreateChatCompletion(ctx context.Context, messages []providers.Message) (string, providers.Usage, error) {
+	var result strings.Builder
+	var totalUsage providers.Usage
+	req := &providers.Request{Messages: messages}
+	if s.schema != nil {
+		req.Schema = s.schema.Format()
+	} else if s.json {
+		req.JSON = true
+	}
+
+	maxRepeat := s.maxCompletionRepeatCount
+	if maxRepeat < 0 {
+		maxRepeat = 0
+	}
+	for i := 0; i <= maxRepeat; i++ {
+		resp, err := s.provider.CreateChatCompletion(ctx, req)
+		if err != nil {
+			return "", totalUsage, fmt.Errorf("failed to send chat message: %w", err)
+		}
+
+		result.WriteString(resp.Content)
+		totalUsage.PromptTokens += resp.Usage.PromptTokens
+		totalUsage.CompletionTokens += resp.Usage.CompletionTokens
+		totalUsage.TotalTokens += resp.Usage.TotalTokens
+
+		if resp.FinishReason != "length" {
+			break
+		}
+
+		req.Messages = append(req.Messages,
+			providers.Message{Role: "assistant", Content: resp.Content},
+			providers.Message{Role: "system", Content: continuationNudge},
+		)
+	}
+
+	return result.String(), totalUsage, nil
 }
 
-func (s *Shaper) ShapeText(promptOrg, inputOrg string) (*ShapeResult, error) {
+// ShapeTextStream behaves like ShapeText, but delivers the response through
+// onChunk as it arrives rather than waiting for the full completion. The
+// code-block post-processing that ShapeText applies eagerly is deferred
+// until the stream ends, since it needs the fully assembled text.
+func (s *Shaper) ShapeTextStream(ctx context.Context, promptOrg, inputOrg string, onChunk func(string)) (*ShapeResult, error) {
 	if inputOrg == "" && !s.promptOptimize {
-		rawResult, err := s.requestCreateChatCompletion(promptOrg)
+		rawResult, err := s.requestCreateChatCompletionStream(ctx, promptOrg, onChunk)
 		if err != nil {
 			return nil, err
 		}
@@ -47,7 +147,7 @@ func (s *Shaper) ShapeText(promptOrg, inputOrg string) (*ShapeResult, error) {
 	}
 
 	optimized := optimizePrompt(promptOrg, inputOrg)
-	rawResult, err := s.requestCreateChatCompletion(optimized)
+	rawResult, err := s.requestCreateChatCompletionStream(ctx, optimized, onChunk)
 	if err != nil {
 		return nil, err
 	}
@@ -58,37 +158,29 @@ func (s *Shaper) ShapeText(promptOrg, inputOrg string) (*ShapeResult, error) {
 	return NewShapeResult(optimized, rawResult, result), nil
 }
 
-func (s *Shaper) requestCreateChatCompletion(prompt string) (string, error) {
-	var result string
-	cr := s.gai.MakeCreateChatCompletion(prompt)
+func (s *Shaper) requestCreateChatCompletionStream(ctx context.Context, prompt string, onChunk func(string)) (string, error) {
+	req := &providers.Request{Messages: []providers.Message{{Role: "user", Content: prompt}}}
 
-	//count := s.maxCompletionRepeatCount
-	maxCount := 1
-	for i := 0; i < maxCount; i++ {
-		comp, err := s.gai.RequestCreateChatCompletion(cr)
+	streamer, ok := s.provider.(providers.StreamingProvider)
+	if !ok {
+		// Provider can't stream; deliver the whole response as one chunk.
+		resp, err := s.provider.CreateChatCompletion(ctx, req)
 		if err != nil {
 			return "", fmt.Errorf("failed to send chat message: %w", err)
 		}
+		onChunk(resp.Content)
+		return resp.Content, nil
+	}
 
-		if comp.Choices == nil || len(comp.Choices) == 0 {
-			break
-		}
-		// use the first choice only
-		choice := comp.Choices[0]
-		result += choice.Message.Content
-		if choice.FinishReason != "length" {
-			break
-		}
-		// can not continue exceed response size limit
-		/*
-			cr.Messages = append(cr.Messages,
-				openai.ChatMessage{Role: "assistant", Content: choice.Message.Content},
-				// openai.ChatMessage{Role: "system", Content: "Continue from where you left off."},
-			)
-		*/
+	var result strings.Builder
+	if _, err := streamer.CreateChatCompletionStream(ctx, req, func(chunk providers.Chunk) {
+		result.WriteString(chunk.Content)
+		onChunk(chunk.Content)
+	}); err != nil {
+		return "", fmt.Errorf("failed to send chat message: %w", err)
 	}
 
-	return result, nil
+	return result.String(), nil
 }
 
 func optimizePrompt(prompt, input string) string {
@@ -0,0 +1,167 @@
+// Package anthropic implements providers.Provider for Anthropic's Messages
+// API (api.anthropic.com).
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ytka/textforge/internal/providers"
+)
+
+// Name is this provider's registry key.
+const Name = "anthropic"
+
+const defaultBaseURL = "https://api.anthropic.com"
+
+const defaultMaxTokens = 4096
+
+// ErrUnexpectedStatusCode is returned when the API responds with a non-2xx
+// status code.
+var ErrUnexpectedStatusCode = errors.New("unexpected status code")
+
+func init() {
+	providers.Register(Name, func(cfg providers.Config) (providers.Provider, error) {
+		baseURL := cfg.BaseURL
+		if baseURL == "" {
+			baseURL = defaultBaseURL
+		}
+		return &Provider{apikey: cfg.APIKey, model: cfg.Model, baseURL: baseURL, maxTokens: cfg.MaxTokens}, nil
+	})
+}
+
+// Provider talks to the Anthropic Messages API.
+type Provider struct {
+	apikey    string
+	model     string
+	baseURL   string
+	maxTokens *int
+}
+
+var _ providers.Provider = (*Provider)(nil)
+
+// Name returns "anthropic".
+func (p *Provider) Name() string { return Name }
+
+type messagesRequest struct {
+	Model     string         `json:"model"`
+	MaxTokens int            `json:"max_tokens"`
+	Messages  []anthropicMsg `json:"messages"`
+	System    string         `json:"system,omitempty"`
+}
+
+type anthropicMsg struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type messagesResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateChatCompletion translates req into Anthropic's Messages API schema,
+// sends it, and translates the reply back into the provider-agnostic
+// Response shape.
+func (p *Provider) CreateChatCompletion(ctx context.Context, req *providers.Request) (*providers.Response, error) {
+	maxTokens := defaultMaxTokens
+	if req.MaxTokens != nil {
+		maxTokens = *req.MaxTokens
+	} else if p.maxTokens != nil {
+		maxTokens = *p.maxTokens
+	}
+
+	model := req.Model
+	if model == "" {
+		model = p.model
+	}
+
+	mr := &messagesRequest{Model: model, MaxTokens: maxTokens}
+	for _, m := range req.Messages {
+		// The Messages API takes system instructions as a top-level field
+		// rather than as a message role.
+		if m.Role == "system" {
+			if mr.System != "" {
+				mr.System += "\n\n"
+			}
+			mr.System += m.Content
+			continue
+		}
+		mr.Messages = append(mr.Messages, anthropicMsg{Role: m.Role, Content: m.Content})
+	}
+
+	body, err := json.Marshal(mr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/v1/messages", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", p.apikey)
+	httpReq.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode > 299 {
+		var errResp errorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal error response: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %d '%s'", ErrUnexpectedStatusCode, resp.StatusCode, errResp.Error.Message)
+	}
+
+	var mresp messagesResponse
+	if err := json.Unmarshal(respBody, &mresp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	var text string
+	if len(mresp.Content) > 0 {
+		text = mresp.Content[0].Text
+	}
+	finishReason := mresp.StopReason
+	if finishReason == "max_tokens" {
+		finishReason = "length"
+	}
+	return &providers.Response{
+		Content:      text,
+		FinishReason: finishReason,
+		Usage: providers.Usage{
+			PromptTokens:     mresp.Usage.InputTokens,
+			CompletionTokens: mresp.Usage.OutputTokens,
+			TotalTokens:      mresp.Usage.InputTokens + mresp.Usage.OutputTokens,
+		},
+	}, nil
+}
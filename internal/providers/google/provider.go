@@ -0,0 +1,160 @@
+// Package google implements providers.Provider for the Gemini
+// generateContent API.
+package google
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ytka/textforge/internal/providers"
+)
+
+// Name is this provider's registry key.
+const Name = "google"
+
+const defaultBaseURL = "https://generativelanguage.googleapis.com"
+
+// ErrUnexpectedStatusCode is returned when the API responds with a non-2xx
+// status code.
+var ErrUnexpectedStatusCode = errors.New("unexpected status code")
+
+func init() {
+	providers.Register(Name, func(cfg providers.Config) (providers.Provider, error) {
+		baseURL := cfg.BaseURL
+		if baseURL == "" {
+			baseURL = defaultBaseURL
+		}
+		return &Provider{apikey: cfg.APIKey, model: cfg.Model, baseURL: baseURL}, nil
+	})
+}
+
+// Provider talks to the Gemini generateContent API.
+type Provider struct {
+	apikey  string
+	model   string
+	baseURL string
+}
+
+var _ providers.Provider = (*Provider)(nil)
+
+// Name returns "google".
+func (p *Provider) Name() string { return Name }
+
+type generateContentRequest struct {
+	Contents []geminiContent `json:"contents"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type generateContentResponse struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+		TotalTokenCount      int `json:"totalTokenCount"`
+	} `json:"usageMetadata"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// geminiRole maps the provider-agnostic "assistant" role onto Gemini's
+// "model" role; every other role passes through unchanged.
+func geminiRole(role string) string {
+	if role == "assistant" {
+		return "model"
+	}
+	return role
+}
+
+// CreateChatCompletion translates req into Gemini's generateContent schema,
+// sends it, and translates the reply back into the provider-agnostic
+// Response shape.
+func (p *Provider) CreateChatCompletion(ctx context.Context, req *providers.Request) (*providers.Response, error) {
+	gr := &generateContentRequest{}
+	for _, m := range req.Messages {
+		gr.Contents = append(gr.Contents, geminiContent{Role: geminiRole(m.Role), Parts: []geminiPart{{Text: m.Content}}})
+	}
+
+	body, err := json.Marshal(gr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	model := req.Model
+	if model == "" {
+		model = p.model
+	}
+
+	url := fmt.Sprintf("%s/v1beta/models/%s:generateContent?key=%s", p.baseURL, model, p.apikey)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode > 299 {
+		var errResp errorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal error response: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %d '%s'", ErrUnexpectedStatusCode, resp.StatusCode, errResp.Error.Message)
+	}
+
+	var gresp generateContentResponse
+	if err := json.Unmarshal(respBody, &gresp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	var text, finishReason string
+	if len(gresp.Candidates) > 0 {
+		cand := gresp.Candidates[0]
+		finishReason = cand.FinishReason
+		if finishReason == "MAX_TOKENS" {
+			finishReason = "length"
+		}
+		if len(cand.Content.Parts) > 0 {
+			text = cand.Content.Parts[0].Text
+		}
+	}
+	return &providers.Response{
+		Content:      text,
+		FinishReason: finishReason,
+		Usage: providers.Usage{
+			PromptTokens:     gresp.UsageMetadata.PromptTokenCount,
+			CompletionTokens: gresp.UsageMetadata.CandidatesTokenCount,
+			TotalTokens:      gresp.UsageMetadata.TotalTokenCount,
+		},
+	}, nil
+}
@@ -0,0 +1,127 @@
+// Package ollama implements providers.Provider for a local or remote Ollama
+// server's chat API.
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ytka/textforge/internal/providers"
+)
+
+// Name is this provider's registry key.
+const Name = "ollama"
+
+const defaultBaseURL = "http://localhost:11434"
+
+// ErrUnexpectedStatusCode is returned when the server responds with a
+// non-2xx status code.
+var ErrUnexpectedStatusCode = errors.New("unexpected status code")
+
+func init() {
+	providers.Register(Name, func(cfg providers.Config) (providers.Provider, error) {
+		baseURL := cfg.BaseURL
+		if baseURL == "" {
+			baseURL = defaultBaseURL
+		}
+		return &Provider{model: cfg.Model, baseURL: baseURL}, nil
+	})
+}
+
+// Provider talks to an Ollama server's /api/chat endpoint. Ollama has no
+// notion of an API key, so unlike the other providers it ignores cfg.APIKey.
+type Provider struct {
+	model   string
+	baseURL string
+}
+
+var _ providers.Provider = (*Provider)(nil)
+
+// Name returns "ollama".
+func (p *Provider) Name() string { return Name }
+
+type chatRequest struct {
+	Model    string      `json:"model"`
+	Messages []ollamaMsg `json:"messages"`
+	Stream   bool        `json:"stream"`
+}
+
+type ollamaMsg struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatResponse struct {
+	Message         ollamaMsg `json:"message"`
+	Done            bool      `json:"done"`
+	DoneReason      string    `json:"done_reason"`
+	PromptEvalCount int       `json:"prompt_eval_count"`
+	EvalCount       int       `json:"eval_count"`
+}
+
+// CreateChatCompletion translates req into Ollama's /api/chat schema, sends
+// it with streaming disabled, and translates the reply back into the
+// provider-agnostic Response shape.
+func (p *Provider) CreateChatCompletion(ctx context.Context, req *providers.Request) (*providers.Response, error) {
+	model := req.Model
+	if model == "" {
+		model = p.model
+	}
+
+	cr := &chatRequest{Model: model, Stream: false}
+	for _, m := range req.Messages {
+		cr.Messages = append(cr.Messages, ollamaMsg{Role: m.Role, Content: m.Content})
+	}
+
+	body, err := json.Marshal(cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/api/chat", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%w: %d '%s'", ErrUnexpectedStatusCode, resp.StatusCode, respBody)
+	}
+
+	var cresp chatResponse
+	if err := json.Unmarshal(respBody, &cresp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	finishReason := cresp.DoneReason
+	if finishReason == "" && cresp.Done {
+		finishReason = "stop"
+	}
+	return &providers.Response{
+		Content:      cresp.Message.Content,
+		FinishReason: finishReason,
+		Usage: providers.Usage{
+			PromptTokens:     cresp.PromptEvalCount,
+			CompletionTokens: cresp.EvalCount,
+			TotalTokens:      cresp.PromptEvalCount + cresp.EvalCount,
+		},
+	}, nil
+}
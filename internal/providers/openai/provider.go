@@ -0,0 +1,188 @@
+// Package openai adapts internal/openai's ChatClient to the
+// providers.Provider interface.
+package openai
+
+import (
+	"context"
+
+	oa "github.com/ytka/textforge/internal/openai"
+	"github.com/ytka/textforge/internal/providers"
+)
+
+// Name is this provider's registry key.
+const Name = "openai"
+
+func init() {
+	providers.Register(Name, func(cfg providers.Config) (providers.Provider, error) {
+		var maxTokens *int
+		if cfg.MaxTokens != nil {
+			maxTokens = cfg.MaxTokens
+		}
+		return &Provider{client: oa.New(oa.APIKey(cfg.APIKey), cfg.Model, cfg.LogAPILevel, maxTokens)}, nil
+	})
+}
+
+// Provider adapts *oa.ChatClient to providers.Provider.
+type Provider struct {
+	client *oa.ChatClient
+}
+
+var (
+	_ providers.Provider          = (*Provider)(nil)
+	_ providers.StreamingProvider = (*Provider)(nil)
+)
+
+// Name returns "openai".
+func (p *Provider) Name() string { return Name }
+
+// CreateChatCompletion translates req into OpenAI's native schema, sends it,
+// and translates the reply back into the provider-agnostic Response shape.
+// When req.Schema is set it goes through the structured-output request path;
+// otherwise when req.JSON is set it goes through the schema-less JSON mode
+// path; otherwise, when req.Tools is non-empty, it goes through the
+// tool-aware request path so the model can return tool calls instead of a
+// final message.
+func (p *Provider) CreateChatCompletion(ctx context.Context, req *providers.Request) (*providers.Response, error) {
+	if req.Schema != nil {
+		return p.createChatCompletionWithSchema(ctx, req)
+	}
+	if req.JSON {
+		return p.createChatCompletionJSON(ctx, req)
+	}
+	if len(req.Tools) > 0 {
+		return p.createChatCompletionWithTools(ctx, req)
+	}
+
+	ccc := p.client.MakeCreateChatCompletion("")
+	ccc.Messages = toChatMessages(req.Messages)
+	comp, err := p.client.RequestCreateChatCompletion(ctx, ccc)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &providers.Response{}
+	if len(comp.Choices) > 0 {
+		choice := comp.Choices[0]
+		resp.Content = choice.Message.Content
+		resp.FinishReason = choice.FinishReason
+	}
+	resp.Usage = providers.Usage{
+		PromptTokens:     comp.Usage.PromptTokens,
+		CompletionTokens: comp.Usage.CompletionTokens,
+		TotalTokens:      comp.Usage.TotalTokens,
+	}
+	return resp, nil
+}
+
+func (p *Provider) createChatCompletionWithTools(ctx context.Context, req *providers.Request) (*providers.Response, error) {
+	ccc := p.client.MakeCreateChatCompletionWithTools(toChatMessages(req.Messages), toChatCompletionTools(req.Tools))
+	result, err := p.client.RequestCreateChatCompletionWithTools(ctx, ccc)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &providers.Response{
+		Content:      result.Content,
+		FinishReason: result.FinishReason,
+		Usage: providers.Usage{
+			PromptTokens:     result.Usage.PromptTokens,
+			CompletionTokens: result.Usage.CompletionTokens,
+			TotalTokens:      result.Usage.TotalTokens,
+		},
+	}
+	for _, tc := range result.ToolCalls {
+		resp.ToolCalls = append(resp.ToolCalls, providers.ToolCall{
+			ID:        tc.ID,
+			ToolName:  tc.Function.Name,
+			Arguments: tc.Function.Arguments,
+		})
+	}
+	return resp, nil
+}
+
+func (p *Provider) createChatCompletionJSON(ctx context.Context, req *providers.Request) (*providers.Response, error) {
+	result, err := p.client.RequestCreateChatCompletionJSON(ctx, toChatMessages(req.Messages))
+	if err != nil {
+		return nil, err
+	}
+
+	return &providers.Response{
+		Content:      result.Content,
+		FinishReason: result.FinishReason,
+		Usage: providers.Usage{
+			PromptTokens:     result.Usage.PromptTokens,
+			CompletionTokens: result.Usage.CompletionTokens,
+			TotalTokens:      result.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+func (p *Provider) createChatCompletionWithSchema(ctx context.Context, req *providers.Request) (*providers.Response, error) {
+	result, err := p.client.RequestCreateChatCompletionWithSchema(ctx, toChatMessages(req.Messages), req.Schema.Name, req.Schema.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &providers.Response{
+		Content:      result.Content,
+		FinishReason: result.FinishReason,
+		Usage: providers.Usage{
+			PromptTokens:     result.Usage.PromptTokens,
+			CompletionTokens: result.Usage.CompletionTokens,
+			TotalTokens:      result.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+func toChatMessages(messages []providers.Message) []oa.ChatMessage {
+	out := make([]oa.ChatMessage, 0, len(messages))
+	for _, m := range messages {
+		out = append(out, oa.ChatMessage{Role: m.Role, Content: m.Content})
+	}
+	return out
+}
+
+func toChatCompletionTools(tools []providers.ToolDefinition) []oa.ChatCompletionTool {
+	out := make([]oa.ChatCompletionTool, 0, len(tools))
+	for _, t := range tools {
+		out = append(out, oa.ChatCompletionTool{
+			Type: "function",
+			Function: oa.ChatCompletionFunction{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.Parameters,
+			},
+		})
+	}
+	return out
+}
+
+// CreateChatCompletionStream behaves like CreateChatCompletion but streams
+// content as it arrives over OpenAI's SSE transport. Only the content of the
+// last message in req is sent, as a single-prompt request.
+func (p *Provider) CreateChatCompletionStream(ctx context.Context, req *providers.Request, onChunk func(providers.Chunk)) (*providers.Response, error) {
+	ccc := p.client.MakeStreamingCreateChatCompletion(lastUserContent(req.Messages))
+	comp, err := p.client.RequestCreateChatCompletionStream(ctx, ccc, func(delta string) {
+		onChunk(providers.Chunk{Content: delta})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &providers.Response{}
+	if len(comp.Choices) > 0 {
+		choice := comp.Choices[0]
+		resp.Content = choice.Message.Content
+		resp.FinishReason = choice.FinishReason
+	}
+	return resp, nil
+}
+
+// lastUserContent returns the content of the last message, which is the
+// single prompt CreateChatCompletionStream sends.
+func lastUserContent(messages []providers.Message) string {
+	if len(messages) == 0 {
+		return ""
+	}
+	return messages[len(messages)-1].Content
+}
@@ -0,0 +1,61 @@
+// Package providers defines a backend-agnostic chat completion interface so
+// that process.Shaper can talk to OpenAI, Anthropic, Ollama, or Google
+// without knowing any of their wire formats.
+package providers
+
+import "context"
+
+// Message is a single turn in a provider-agnostic chat request. Role is one
+// of "system", "user", "assistant", or "tool"; ToolCallID identifies which
+// ToolCall a "tool" message is answering.
+type Message struct {
+	Role       string
+	Content    string
+	ToolCallID string
+}
+
+// Usage captures token accounting in the shape every provider can report,
+// even though the underlying field names differ.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// Request is the provider-agnostic form of a chat completion request.
+type Request struct {
+	Model     string
+	Messages  []Message
+	MaxTokens *int
+	// Tools lists the functions the model may call. Providers that don't
+	// support function calling ignore it.
+	Tools []ToolDefinition
+	// Schema requests a JSON-Schema-validated reply instead of free text.
+	// Providers that don't support structured output ignore it.
+	Schema *SchemaFormat
+	// JSON requests a reply that's valid JSON without constraining it to
+	// any particular shape, for callers that want --json without paying
+	// for a schema file. Ignored when Schema is set, and by providers that
+	// don't support a dedicated JSON mode.
+	JSON bool
+}
+
+// Response is the provider-agnostic form of a chat completion response.
+type Response struct {
+	Content      string
+	FinishReason string
+	Usage        Usage
+	// ToolCalls is populated when FinishReason is FinishReasonToolCalls;
+	// Content then holds any text the model sent alongside the calls.
+	ToolCalls []ToolCall
+}
+
+// Provider is implemented by each backend so that process.Shaper can drive
+// any of them through the same interface.
+type Provider interface {
+	// Name returns the provider's registry key, e.g. "openai".
+	Name() string
+	// CreateChatCompletion sends req to the backend and returns its reply
+	// translated into the provider-agnostic Response shape.
+	CreateChatCompletion(ctx context.Context, req *Request) (*Response, error)
+}
@@ -0,0 +1,51 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownProvider is returned by New when no factory is registered under
+// the requested name.
+var ErrUnknownProvider = errors.New("unknown provider")
+
+// Config bundles the settings a Factory needs to build a Provider.
+type Config struct {
+	Model       string
+	BaseURL     string
+	APIKey      string
+	LogAPILevel string
+	MaxTokens   *int
+}
+
+// Factory builds a Provider from a Config.
+type Factory func(cfg Config) (Provider, error)
+
+var factories = map[string]Factory{}
+
+// Register adds a provider factory under name. Each provider package calls
+// this from its init function so importing it for side effects is enough to
+// make it available through New.
+func Register(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// New builds the named provider from cfg.
+func New(name string, cfg Config) (Provider, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (available: %v)", ErrUnknownProvider, name, Names())
+	}
+	return factory(cfg)
+}
+
+// Names returns the registered provider names in sorted order.
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,12 @@
+package providers
+
+// SchemaFormat requests that the model's reply conform to a JSON Schema,
+// mirroring OpenAI's response_format: json_schema mode. Providers that don't
+// support structured output ignore it.
+type SchemaFormat struct {
+	// Name identifies the schema in the request (OpenAI requires a short
+	// machine-readable name alongside the schema body).
+	Name string
+	// Schema is the JSON Schema document itself, decoded into a plain map.
+	Schema map[string]any
+}
@@ -0,0 +1,21 @@
+package providers
+
+import "context"
+
+// Chunk is one piece of a streamed response.
+type Chunk struct {
+	Content      string
+	FinishReason string
+}
+
+// StreamingProvider is implemented by providers that can stream partial
+// output as it's generated. Providers that don't implement it are still
+// usable through Provider alone — callers fall back to a single
+// CreateChatCompletion call and treat the whole response as one chunk.
+type StreamingProvider interface {
+	Provider
+	// CreateChatCompletionStream behaves like CreateChatCompletion but
+	// invokes onChunk as each piece of content arrives, in addition to
+	// returning the fully assembled Response once the stream ends.
+	CreateChatCompletionStream(ctx context.Context, req *Request, onChunk func(Chunk)) (*Response, error)
+}
@@ -0,0 +1,23 @@
+package providers
+
+// ToolDefinition describes a callable tool in provider-agnostic form, built
+// from a toolbox.Tool so providers that support function calling (currently
+// OpenAI) can advertise it to the model.
+type ToolDefinition struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+}
+
+// ToolCall is a single invocation the model asked for in response to a
+// request that included Tools.
+type ToolCall struct {
+	ID        string
+	ToolName  string
+	Arguments string // raw JSON object matching the tool's Parameters
+}
+
+// FinishReasonToolCalls is the provider-agnostic finish reason reported when
+// the model wants to call one or more tools instead of producing a final
+// answer.
+const FinishReasonToolCalls = "tool_calls"
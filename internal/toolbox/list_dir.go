@@ -0,0 +1,54 @@
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ListDirTool lists the entries of a directory on the working tree.
+type ListDirTool struct{}
+
+var _ Tool = ListDirTool{}
+
+func (ListDirTool) Name() string { return "list_dir" }
+
+func (ListDirTool) Description() string {
+	return "List the files and subdirectories of a directory at the given path."
+}
+
+func (ListDirTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"path": map[string]any{"type": "string", "description": "Path to the directory, relative to the working directory."},
+		},
+		"required": []string{"path"},
+	}
+}
+
+func (ListDirTool) Execute(_ context.Context, argsJSON string) (string, error) {
+	var args struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		return "", fmt.Errorf("failed to unmarshal list_dir arguments: %w", err)
+	}
+
+	entries, err := os.ReadDir(args.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to list %s: %w", args.Path, err)
+	}
+
+	var sb strings.Builder
+	for _, entry := range entries {
+		if entry.IsDir() {
+			sb.WriteString(entry.Name() + "/\n")
+			continue
+		}
+		sb.WriteString(entry.Name() + "\n")
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,100 @@
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ModifyFileTool applies diff-style line edits to a file: each edit replaces
+// the inclusive line range [StartLine, EndLine] (1-indexed) with NewContent.
+type ModifyFileTool struct{}
+
+var _ Tool = ModifyFileTool{}
+
+func (ModifyFileTool) Name() string { return "modify_file" }
+
+func (ModifyFileTool) Description() string {
+	return "Apply one or more line-range replacements to a file. Lines are 1-indexed and inclusive; " +
+		"an edit with start_line == end_line + 1 inserts without replacing any lines."
+}
+
+func (ModifyFileTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"path": map[string]any{"type": "string", "description": "Path to the file, relative to the working directory."},
+			"edits": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"start_line":  map[string]any{"type": "integer"},
+						"end_line":    map[string]any{"type": "integer"},
+						"new_content": map[string]any{"type": "string"},
+					},
+					"required": []string{"start_line", "end_line", "new_content"},
+				},
+			},
+		},
+		"required": []string{"path", "edits"},
+	}
+}
+
+type lineEdit struct {
+	StartLine  int    `json:"start_line"`
+	EndLine    int    `json:"end_line"`
+	NewContent string `json:"new_content"`
+}
+
+func (ModifyFileTool) Execute(_ context.Context, argsJSON string) (string, error) {
+	var args struct {
+		Path  string     `json:"path"`
+		Edits []lineEdit `json:"edits"`
+	}
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		return "", fmt.Errorf("failed to unmarshal modify_file arguments: %w", err)
+	}
+
+	data, err := os.ReadFile(args.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", args.Path, err)
+	}
+
+	// Every edit's StartLine/EndLine refers to the original file, so apply
+	// them bottom-to-top: once an edit whose replacement adds or removes
+	// lines runs, every edit above it in the file is still valid, but one
+	// below it would now be targeting the wrong range.
+	edits := make([]lineEdit, len(args.Edits))
+	copy(edits, args.Edits)
+	sort.Slice(edits, func(i, j int) bool { return edits[i].StartLine > edits[j].StartLine })
+
+	lines := strings.Split(string(data), "\n")
+	for _, edit := range edits {
+		lines, err = applyLineEdit(lines, edit)
+		if err != nil {
+			return "", fmt.Errorf("failed to apply edit to %s: %w", args.Path, err)
+		}
+	}
+
+	if err := os.WriteFile(args.Path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", args.Path, err)
+	}
+	return fmt.Sprintf("applied %d edit(s) to %s", len(args.Edits), args.Path), nil
+}
+
+func applyLineEdit(lines []string, edit lineEdit) ([]string, error) {
+	if edit.StartLine < 1 || edit.EndLine < edit.StartLine-1 || edit.EndLine > len(lines) {
+		return nil, fmt.Errorf("edit range [%d,%d] is out of bounds for a %d-line file", edit.StartLine, edit.EndLine, len(lines))
+	}
+
+	newLines := strings.Split(edit.NewContent, "\n")
+	out := make([]string, 0, len(lines)+len(newLines))
+	out = append(out, lines[:edit.StartLine-1]...)
+	out = append(out, newLines...)
+	out = append(out, lines[edit.EndLine:]...)
+	return out, nil
+}
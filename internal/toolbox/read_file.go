@@ -0,0 +1,44 @@
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ReadFileTool reads and returns the contents of a file on the working tree.
+type ReadFileTool struct{}
+
+var _ Tool = ReadFileTool{}
+
+func (ReadFileTool) Name() string { return "read_file" }
+
+func (ReadFileTool) Description() string {
+	return "Read the full contents of a file at the given path."
+}
+
+func (ReadFileTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"path": map[string]any{"type": "string", "description": "Path to the file, relative to the working directory."},
+		},
+		"required": []string{"path"},
+	}
+}
+
+func (ReadFileTool) Execute(_ context.Context, argsJSON string) (string, error) {
+	var args struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		return "", fmt.Errorf("failed to unmarshal read_file arguments: %w", err)
+	}
+
+	data, err := os.ReadFile(args.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", args.Path, err)
+	}
+	return string(data), nil
+}
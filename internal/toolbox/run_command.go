@@ -0,0 +1,74 @@
+package toolbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrCommandNotAllowed is returned when a command isn't on the allow-list.
+var ErrCommandNotAllowed = errors.New("command not allow-listed")
+
+// RunCommandTool runs a shell command, restricted to an allow-list of
+// program names so an agent can't be steered into running arbitrary
+// commands.
+type RunCommandTool struct {
+	Allowed []string
+}
+
+var _ Tool = RunCommandTool{}
+
+func (RunCommandTool) Name() string { return "run_command" }
+
+func (RunCommandTool) Description() string {
+	return "Run a shell command and return its combined stdout/stderr. Only allow-listed programs may be run."
+}
+
+func (RunCommandTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"command": map[string]any{"type": "string", "description": "The full command line to run."},
+		},
+		"required": []string{"command"},
+	}
+}
+
+func (t RunCommandTool) Execute(ctx context.Context, argsJSON string) (string, error) {
+	var args struct {
+		Command string `json:"command"`
+	}
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		return "", fmt.Errorf("failed to unmarshal run_command arguments: %w", err)
+	}
+
+	fields := strings.Fields(args.Command)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty command")
+	}
+	if !t.isAllowed(fields[0]) {
+		return "", fmt.Errorf("%w: %s", ErrCommandNotAllowed, fields[0])
+	}
+
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("command failed: %w\n%s", err, out.String())
+	}
+	return out.String(), nil
+}
+
+func (t RunCommandTool) isAllowed(program string) bool {
+	for _, allowed := range t.Allowed {
+		if allowed == program {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+// Package toolbox provides the built-in tools an agent can call: reading and
+// modifying files, running allow-listed shell commands, listing directories,
+// and searching the web.
+package toolbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownTool is returned by Registry.Get when name isn't registered.
+var ErrUnknownTool = errors.New("unknown tool")
+
+// Tool is a single callable action an agent can invoke.
+type Tool interface {
+	// Name is the identifier the model uses to call this tool, e.g. "read_file".
+	Name() string
+	// Description is shown to the model so it knows when to call this tool.
+	Description() string
+	// Parameters is the JSON Schema object describing the tool's arguments.
+	Parameters() map[string]any
+	// Execute runs the tool against argsJSON (a JSON object matching
+	// Parameters) and returns its result as text to feed back to the model.
+	Execute(ctx context.Context, argsJSON string) (string, error)
+}
+
+// Registry holds the set of tools available to an agent, keyed by name.
+type Registry struct {
+	tools map[string]Tool
+}
+
+// NewRegistry builds a Registry containing tools.
+func NewRegistry(tools ...Tool) *Registry {
+	r := &Registry{tools: make(map[string]Tool, len(tools))}
+	for _, t := range tools {
+		r.tools[t.Name()] = t
+	}
+	return r
+}
+
+// Get looks up a tool by name.
+func (r *Registry) Get(name string) (Tool, error) {
+	t, ok := r.tools[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTool, name)
+	}
+	return t, nil
+}
+
+// All returns every registered tool.
+func (r *Registry) All() []Tool {
+	tools := make([]Tool, 0, len(r.tools))
+	for _, t := range r.tools {
+		tools = append(tools, t)
+	}
+	return tools
+}
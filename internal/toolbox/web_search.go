@@ -0,0 +1,87 @@
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// WebSearchTool queries a search API and returns the result snippets as
+// text. BaseURL and APIKey point it at the configured backend (e.g. a
+// Bing/Brave/SerpAPI-compatible endpoint).
+type WebSearchTool struct {
+	BaseURL string
+	APIKey  string
+}
+
+var _ Tool = WebSearchTool{}
+
+func (WebSearchTool) Name() string { return "web_search" }
+
+func (WebSearchTool) Description() string {
+	return "Search the web and return the top result snippets for a query."
+}
+
+func (WebSearchTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{"type": "string", "description": "The search query."},
+		},
+		"required": []string{"query"},
+	}
+}
+
+type webSearchResult struct {
+	Results []struct {
+		Title   string `json:"title"`
+		URL     string `json:"url"`
+		Snippet string `json:"snippet"`
+	} `json:"results"`
+}
+
+func (t WebSearchTool) Execute(ctx context.Context, argsJSON string) (string, error) {
+	var args struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		return "", fmt.Errorf("failed to unmarshal web_search arguments: %w", err)
+	}
+
+	reqURL := fmt.Sprintf("%s?q=%s", t.BaseURL, url.QueryEscape(args.Query))
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create search request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.APIKey))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("search request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read search response: %w", err)
+	}
+	if resp.StatusCode > 299 {
+		return "", fmt.Errorf("search request returned status %d: %s", resp.StatusCode, body)
+	}
+
+	var result webSearchResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal search response: %w", err)
+	}
+
+	out := ""
+	for _, r := range result.Results {
+		out += fmt.Sprintf("- %s (%s)\n  %s\n", r.Title, r.URL, r.Snippet)
+	}
+	return out, nil
+}